@@ -0,0 +1,400 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// Attributes describes the current user/request for feature evaluation
+// purposes. Values are converted to the internal value package's Value type
+// via value.New, so any JSON-marshalable Go value is accepted.
+type Attributes map[string]any
+
+// FeatureUsageCallback is invoked every time EvalFeature returns a result,
+// useful for feature-usage analytics.
+type FeatureUsageCallback func(ctx context.Context, key string, result *FeatureResult, extraData any)
+
+// ExperimentCallback is invoked every time a user is put into an experiment,
+// useful for experiment exposure tracking.
+type ExperimentCallback func(ctx context.Context, exp *Experiment, result *ExperimentResult, extraData any)
+
+// clientData holds the feature/experiment definitions a Client evaluates
+// against. It's shared (via pointer) between a Client and any child clients
+// derived from it with WithAttributes/WithEnabled/etc, so updating it (e.g.
+// via UpdateFromApiResponseJSON) is visible to every client sharing it.
+//
+// features/experiments/dateUpdated can be written from a StartStream
+// goroutine concurrently with EvalFeature/SetFeatures/etc on other
+// goroutines, so every access goes through mu.
+type clientData struct {
+	mu          sync.RWMutex
+	features    FeatureMap
+	experiments []*Experiment
+	dateUpdated time.Time
+
+	updateHooks []FeatureUpdateHook
+}
+
+func (d *clientData) getFeature(key string) (*Feature, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	f, ok := d.features[key]
+	return f, ok
+}
+
+func (d *clientData) getFeatures() FeatureMap {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.features
+}
+
+func (d *clientData) setFeatures(features FeatureMap) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.features = features
+}
+
+// applyApiResponse applies resp's features/experiments/dateUpdated unless
+// resp.DateUpdated is not newer than what's already stored, returning
+// whether it was applied. A response with no dateUpdated is always applied.
+func (d *clientData) applyApiResponse(resp apiResponse) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !resp.DateUpdated.IsZero() && !d.dateUpdated.IsZero() && !resp.DateUpdated.After(d.dateUpdated) {
+		return false
+	}
+	d.features = resp.Features
+	d.experiments = resp.Experiments
+	d.dateUpdated = resp.DateUpdated
+	return true
+}
+
+// Client evaluates features and experiments for a particular user/request,
+// described by its attributes. Immutable-update methods (WithAttributes,
+// WithEnabled, ...) return a derived child client rather than mutating the
+// receiver, so a single base client can safely be reused across requests.
+type Client struct {
+	data *clientData
+
+	attributes     value.ObjValue
+	requestContext *RequestContext
+	enabled        bool
+	qaMode         bool
+	decryptionKey  string
+	extraData      any
+
+	featureUsageCallback FeatureUsageCallback
+	experimentCallback   ExperimentCallback
+}
+
+// ClientOption configures a Client at construction time, via NewClient.
+type ClientOption func(*Client) error
+
+// NewClient builds a Client with no features and no attributes, applying
+// opts in order.
+func NewClient(ctx context.Context, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		data:       &clientData{features: FeatureMap{}},
+		attributes: value.ObjValue{},
+		enabled:    true,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Client) clone() *Client {
+	nc := *c
+	return &nc
+}
+
+// WithAttributes sets the initial attributes on a client under construction.
+func WithAttributes(attrs Attributes) ClientOption {
+	return func(c *Client) error {
+		c.attributes, _ = value.New(attrs).(value.ObjValue)
+		return nil
+	}
+}
+
+// WithEnabled sets whether a client under construction evaluates features at
+// all; a disabled client always falls through to default values.
+func WithEnabled(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.enabled = enabled
+		return nil
+	}
+}
+
+// WithQaMode sets whether a client under construction runs in QA mode, which
+// disables experiment bucketing so QA can force variations deterministically.
+func WithQaMode(qaMode bool) ClientOption {
+	return func(c *Client) error {
+		c.qaMode = qaMode
+		return nil
+	}
+}
+
+// WithFeatures seeds a client under construction with an initial feature map.
+func WithFeatures(features FeatureMap) ClientOption {
+	return func(c *Client) error {
+		c.data.setFeatures(features)
+		return nil
+	}
+}
+
+// WithDecryptionKey sets the key used to decrypt payloads passed to
+// SetEncryptedJSONFeatures.
+func WithDecryptionKey(key string) ClientOption {
+	return func(c *Client) error {
+		c.decryptionKey = key
+		return nil
+	}
+}
+
+// WithExtraData sets opaque data passed through to usage/experiment
+// callbacks on a client under construction.
+func WithExtraData(extraData any) ClientOption {
+	return func(c *Client) error {
+		c.extraData = extraData
+		return nil
+	}
+}
+
+// WithFeatureUsageCallback registers a callback invoked on every EvalFeature
+// call.
+func WithFeatureUsageCallback(cb FeatureUsageCallback) ClientOption {
+	return func(c *Client) error {
+		c.featureUsageCallback = cb
+		return nil
+	}
+}
+
+// WithExperimentCallback registers a callback invoked whenever EvalFeature
+// puts the current attributes into an experiment.
+func WithExperimentCallback(cb ExperimentCallback) ClientOption {
+	return func(c *Client) error {
+		c.experimentCallback = cb
+		return nil
+	}
+}
+
+// WithAttributes returns a child client with attrs in place of the
+// receiver's attributes. The child shares the receiver's feature data.
+func (c *Client) WithAttributes(attrs Attributes) (*Client, error) {
+	nc := c.clone()
+	nc.attributes, _ = value.New(attrs).(value.ObjValue)
+	return nc, nil
+}
+
+// WithEnabled returns a child client with enabled in place of the receiver's.
+func (c *Client) WithEnabled(enabled bool) (*Client, error) {
+	nc := c.clone()
+	nc.enabled = enabled
+	return nc, nil
+}
+
+// WithQaMode returns a child client with qaMode in place of the receiver's.
+func (c *Client) WithQaMode(qaMode bool) (*Client, error) {
+	nc := c.clone()
+	nc.qaMode = qaMode
+	return nc, nil
+}
+
+// WithExtraData returns a child client whose usage/experiment callbacks
+// receive extraData instead of the receiver's.
+func (c *Client) WithExtraData(extraData any) (*Client, error) {
+	nc := c.clone()
+	nc.extraData = extraData
+	return nc, nil
+}
+
+// SetFeatures replaces the client's feature map.
+func (c *Client) SetFeatures(features FeatureMap) {
+	c.data.setFeatures(features)
+}
+
+// SetJSONFeatures replaces the client's feature map, decoded from a
+// GrowthBook features JSON payload (the same shape as the "features" field
+// of the API response).
+func (c *Client) SetJSONFeatures(featuresJSON string) error {
+	var features FeatureMap
+	if err := json.Unmarshal([]byte(featuresJSON), &features); err != nil {
+		return err
+	}
+	c.SetFeatures(features)
+	return nil
+}
+
+// SetEncryptedJSONFeatures decrypts encryptedFeaturesJSON with the client's
+// decryption key (see WithDecryptionKey) and replaces the feature map with
+// the result.
+func (c *Client) SetEncryptedJSONFeatures(encryptedFeaturesJSON string) error {
+	decrypted, err := decrypt(encryptedFeaturesJSON, c.decryptionKey)
+	if err != nil {
+		return err
+	}
+	return c.SetJSONFeatures(decrypted)
+}
+
+// apiResponse mirrors the payload shape of a GrowthBook features API
+// response / SSE event.
+type apiResponse struct {
+	Features    FeatureMap    `json:"features"`
+	Experiments []*Experiment `json:"experiments"`
+	DateUpdated time.Time     `json:"dateUpdated"`
+}
+
+// UpdateFromApiResponseJSON replaces the client's features and experiments
+// from a GrowthBook API response payload, unless its dateUpdated is not
+// newer than the most recent update already applied -- this guards against
+// an out-of-order response (e.g. from a stream reconnect) clobbering newer
+// data with stale data. A payload without a dateUpdated is always applied.
+func (c *Client) UpdateFromApiResponseJSON(apiResponseJSON string) error {
+	var resp apiResponse
+	dec := json.NewDecoder(strings.NewReader(apiResponseJSON))
+	dec.UseNumber()
+	if err := dec.Decode(&resp); err != nil {
+		return err
+	}
+	c.data.applyApiResponse(resp)
+	return nil
+}
+
+// EvalFeature evaluates the feature identified by key against the client's
+// current attributes, returning its value, whether it's "on" (truthy), and
+// which rule (if any) determined the result.
+func (c *Client) EvalFeature(ctx context.Context, key string) *FeatureResult {
+	feature, ok := c.data.getFeature(key)
+	if !ok {
+		result := &FeatureResult{Source: UnknownFeatureResultSource, Off: true}
+		c.fireFeatureUsage(ctx, key, result)
+		return result
+	}
+
+	if c.enabled {
+		for _, rule := range feature.Rules {
+			if rule.Condition != nil && !rule.Condition.Eval(c.attributes, c.requestContext) {
+				continue
+			}
+
+			if len(rule.Variations) > 0 {
+				result, ok := c.runExperimentRule(ctx, key, rule)
+				if ok {
+					c.fireFeatureUsage(ctx, key, result)
+					return result
+				}
+				continue
+			}
+
+			if rule.Force != nil {
+				result := featureResultFromValue(rule.Force, ForceResultSource)
+				result.RuleID = rule.Key
+				c.fireFeatureUsage(ctx, key, result)
+				return result
+			}
+		}
+	}
+
+	result := featureResultFromValue(feature.DefaultValue, DefaultValueResultSource)
+	c.fireFeatureUsage(ctx, key, result)
+	return result
+}
+
+// runExperimentRule buckets the current attributes into the experiment
+// described by rule, returning ok=false if the rule doesn't apply (e.g. the
+// hash attribute is missing, or coverage excludes this user).
+func (c *Client) runExperimentRule(ctx context.Context, featureKey string, rule *FeatureRule) (*FeatureResult, bool) {
+	if c.qaMode {
+		return nil, false
+	}
+
+	hashAttribute := rule.HashAttribute
+	if hashAttribute == "" {
+		hashAttribute = "id"
+	}
+	hashValue, ok := toHashString(c.attributes.Path(hashAttribute))
+	if !ok {
+		return nil, false
+	}
+
+	weights := rule.Weights
+	if len(weights) == 0 {
+		weights = equalWeights(len(rule.Variations))
+	}
+
+	expKey := rule.Key
+	if expKey == "" {
+		expKey = featureKey
+	}
+
+	// Coverage scales every variation's share, so a ratio past the scaled
+	// total falls outside all of them and the user is excluded.
+	bucketWeights := weights
+	if rule.Coverage != nil {
+		bucketWeights = make([]float64, len(weights))
+		for i, w := range weights {
+			bucketWeights[i] = w * *rule.Coverage
+		}
+	}
+	variationID := chooseVariation(hashRatio(expKey, hashValue), bucketWeights)
+	if variationID < 0 {
+		return nil, false
+	}
+
+	exp := &Experiment{
+		Key:           expKey,
+		Variations:    rule.Variations,
+		Weights:       weights,
+		Coverage:      rule.Coverage,
+		HashAttribute: hashAttribute,
+	}
+	expResult := &ExperimentResult{
+		Value:         rule.Variations[variationID],
+		VariationID:   variationID,
+		InExperiment:  true,
+		HashUsed:      true,
+		HashAttribute: hashAttribute,
+		HashValue:     hashValue,
+		Key:           expKey,
+		FeatureID:     featureKey,
+	}
+
+	result := featureResultFromValue(expResult.Value, ExperimentResultSource)
+	result.RuleID = rule.Key
+	result.Experiment = exp
+	result.ExperimentResult = expResult
+
+	c.fireExperimentUsage(ctx, exp, expResult)
+	return result, true
+}
+
+func toHashString(v value.Value) (string, bool) {
+	if value.IsNull(v) {
+		return "", false
+	}
+	s, ok := v.Cast(value.StrType).(value.StrValue)
+	if !ok {
+		return "", false
+	}
+	return string(s), true
+}
+
+func (c *Client) fireFeatureUsage(ctx context.Context, key string, result *FeatureResult) {
+	if c.featureUsageCallback != nil {
+		c.featureUsageCallback(ctx, key, result, c.extraData)
+	}
+}
+
+func (c *Client) fireExperimentUsage(ctx context.Context, exp *Experiment, result *ExperimentResult) {
+	if c.experimentCallback != nil {
+		c.experimentCallback(ctx, exp, result, c.extraData)
+	}
+}
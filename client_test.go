@@ -3,6 +3,10 @@ package growthbook
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 
 	"github.com/growthbook/growthbook-golang/internal/value"
@@ -149,6 +153,193 @@ func TestClientNoUpdatesFromStaleApiData(t *testing.T) {
 	require.Equal(t, client.data.features["foo"], &Feature{DefaultValue: "api2"})
 }
 
+func TestClientEvalFeatureLargeIntegerCondition(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx, WithAttributes(Attributes{"id": int64(9007199254740993)}))
+	featuresJSON := `{
+      "feature": {
+        "defaultValue": "default",
+        "rules": [{"condition": {"id": 9007199254740993}, "force": "matched"}]
+      }
+    }`
+	err := client.SetJSONFeatures(featuresJSON)
+	require.Nil(t, err)
+
+	result := client.EvalFeature(ctx, "feature")
+	require.Equal(t, "matched", result.Value, "an int64 attribute must match an equivalent large integer condition without float64 rounding")
+
+	neighbor, _ := client.WithAttributes(Attributes{"id": int64(9007199254740992)})
+	result = neighbor.EvalFeature(ctx, "feature")
+	require.Equal(t, "default", result.Value, "a neighboring large integer must not match due to float64 rounding")
+}
+
+func TestClientUpdateFromApiResponseJSONLargeInteger(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx, WithAttributes(Attributes{"id": int64(9007199254740993)}))
+	apiJSON := `{
+      "features": {
+        "feature": {
+          "defaultValue": "default",
+          "rules": [{"condition": {"id": 9007199254740993}, "force": "matched"}]
+        }
+      },
+      "experiments": [],
+      "dateUpdated": "2000-05-01T00:00:12Z"
+    }`
+	err := client.UpdateFromApiResponseJSON(apiJSON)
+	require.Nil(t, err)
+
+	result := client.EvalFeature(ctx, "feature")
+	require.Equal(t, "matched", result.Value)
+}
+
+func TestClientRequestContextTargeting(t *testing.T) {
+	ctx := context.TODO()
+	featuresJSON := `{
+      "feature": {
+        "defaultValue": "default",
+        "rules": [{"condition": {"headers.X-Country": "US", "cookies.flag": "on"}, "force": "matched"}]
+      }
+    }`
+
+	t.Run("WithRequestContext", func(t *testing.T) {
+		client, _ := NewClient(ctx)
+		err := client.SetJSONFeatures(featuresJSON)
+		require.Nil(t, err)
+
+		matching, _ := client.WithRequestContext(&RequestContext{
+			Headers: map[string]string{"X-Country": "US"},
+			Cookies: map[string]string{"flag": "on"},
+		})
+		require.Equal(t, "matched", matching.EvalFeature(ctx, "feature").Value)
+
+		nonMatching, _ := client.WithRequestContext(&RequestContext{
+			Headers: map[string]string{"X-Country": "CA"},
+			Cookies: map[string]string{"flag": "on"},
+		})
+		require.Equal(t, "default", nonMatching.EvalFeature(ctx, "feature").Value)
+	})
+
+	t.Run("WithRequest", func(t *testing.T) {
+		client, _ := NewClient(ctx)
+		err := client.SetJSONFeatures(featuresJSON)
+		require.Nil(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Country", "US")
+		req.AddCookie(&http.Cookie{Name: "flag", Value: "on"})
+
+		reqClient, _ := client.WithRequest(req)
+		require.Equal(t, "matched", reqClient.EvalFeature(ctx, "feature").Value)
+	})
+
+	t.Run("operator object against a request field", func(t *testing.T) {
+		inJSON := `{
+          "feature": {
+            "defaultValue": "default",
+            "rules": [{"condition": {"headers.X-Country": {"$in": ["US", "CA"]}}, "force": "matched"}]
+          }
+        }`
+		client, _ := NewClient(ctx)
+		err := client.SetJSONFeatures(inJSON)
+		require.Nil(t, err)
+
+		matching, _ := client.WithRequestContext(&RequestContext{Headers: map[string]string{"X-Country": "CA"}})
+		require.Equal(t, "matched", matching.EvalFeature(ctx, "feature").Value)
+
+		nonMatching, _ := client.WithRequestContext(&RequestContext{Headers: map[string]string{"X-Country": "FR"}})
+		require.Equal(t, "default", nonMatching.EvalFeature(ctx, "feature").Value)
+	})
+
+	t.Run("does not shadow a literal attribute named headers when there is no request context", func(t *testing.T) {
+		shadowJSON := `{
+          "feature": {
+            "defaultValue": "default",
+            "rules": [{"condition": {"headers": "present"}, "force": "matched"}]
+          }
+        }`
+		client, _ := NewClient(ctx, WithAttributes(Attributes{"headers": "present"}))
+		err := client.SetJSONFeatures(shadowJSON)
+		require.Nil(t, err)
+		require.Equal(t, "matched", client.EvalFeature(ctx, "feature").Value)
+	})
+
+	t.Run("does not shadow a literal attribute named headers when a request context is present", func(t *testing.T) {
+		shadowJSON := `{
+          "feature": {
+            "defaultValue": "default",
+            "rules": [{"condition": {"headers": "present"}, "force": "matched"}]
+          }
+        }`
+		client, _ := NewClient(ctx, WithAttributes(Attributes{"headers": "present"}))
+		err := client.SetJSONFeatures(shadowJSON)
+		require.Nil(t, err)
+
+		reqClient, _ := client.WithRequestContext(&RequestContext{Headers: map[string]string{"X-Country": "US"}})
+		require.Equal(t, "matched", reqClient.EvalFeature(ctx, "feature").Value)
+	})
+}
+
+func TestClientEvalFeatureNestedArrayCondition(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx, WithAttributes(Attributes{
+		"orders": []any{
+			map[string]any{"sku": "a", "total": 10},
+			map[string]any{"sku": "vip-upgrade", "total": 500},
+		},
+	}))
+	featuresJSON := `{
+      "feature": {
+        "defaultValue": "default",
+        "rules": [{"condition": {"orders.#(sku==\"vip-upgrade\").total": {"$gte": 100}}, "force": "matched"}]
+      }
+    }`
+	err := client.SetJSONFeatures(featuresJSON)
+	require.Nil(t, err)
+
+	result := client.EvalFeature(ctx, "feature")
+	require.Equal(t, "matched", result.Value)
+}
+
+func TestClientConcurrentEvalFeatureAndUpdate(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx)
+	err := client.SetJSONFeatures(`{"feature": {"defaultValue": "initial"}}`)
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			client.EvalFeature(ctx, "feature")
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = client.UpdateFromApiResponseJSON(fmt.Sprintf(
+				`{"features": {"feature": {"defaultValue": "updated"}}, "experiments": [], "dateUpdated": "2000-05-%02dT00:00:00Z"}`,
+				(i%28)+1,
+			))
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestClientSetJSONFeaturesLargeIntegerDefaultValue(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx)
+	featuresJSON := `{"feature": {"defaultValue": 9007199254740993}}`
+	err := client.SetJSONFeatures(featuresJSON)
+	require.Nil(t, err)
+	require.Equal(t, int64(9007199254740993), client.data.features["feature"].DefaultValue,
+		"a DefaultValue outside float64's exact-integer range must round-trip as int64, not a rounded float64")
+}
+
 func TestClientFeatureUsageTracking(t *testing.T) {
 	ctx := context.TODO()
 	count := 0
@@ -0,0 +1,168 @@
+package growthbook
+
+import (
+	"strings"
+
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// splitPath turns a dotted attribute path ("user.id") into the key sequence
+// ObjValue.Path expects.
+func splitPath(path string) []string {
+	return strings.Split(path, ".")
+}
+
+// resolveConditionPath looks up a condition field path in attrs. Paths using
+// gjson-style array/filter syntax ("orders.0.total", "orders.#(sku==\"x\")")
+// go through ObjValue.PathExpr; plain dotted paths use the simpler
+// ObjValue.Path, which doesn't need to parse anything.
+func resolveConditionPath(attrs value.ObjValue, path string) value.Value {
+	if strings.ContainsAny(path, "[]#*") {
+		return attrs.PathExpr(path)
+	}
+	return attrs.Path(splitPath(path)...)
+}
+
+// evalConditionValue compares an attribute value against a single condition
+// field. expected is either a plain value (equality check) or an object
+// whose keys are all MongoDB-style operators ($eq, $in, $exists, ...).
+func evalConditionValue(actual, expected value.Value) bool {
+	if obj, ok := expected.(value.ObjValue); ok && isOperatorObject(obj) {
+		for op, arg := range obj {
+			if !evalOperator(op, actual, arg) {
+				return false
+			}
+		}
+		return true
+	}
+	return value.Equal(actual, expected)
+}
+
+// evalRequestField evaluates a single request-namespaced condition field
+// (see Condition.Eval) against reqCtx. An operator-object expected value
+// (e.g. {"$in": [...]}) is evaluated against the field's actual string value
+// the same way a normal attribute operator is; any other expected value is
+// treated as a glob/wildcard pattern, matched with the same semantics
+// evalSimpleURLTarget/evalSimpleURLPart already apply to URL targeting.
+func evalRequestField(reqCtx *RequestContext, namespace, key string, expected value.Value) bool {
+	if obj, ok := expected.(value.ObjValue); ok && isOperatorObject(obj) {
+		actualStr, ok := requestFieldValue(reqCtx, namespace, key)
+		actual := value.Null()
+		if ok {
+			actual = value.Str(actualStr)
+		}
+		return evalConditionValue(actual, expected)
+	}
+	pattern, _ := expected.Cast(value.StrType).(value.StrValue)
+	return evalRequestTarget(reqCtx, namespace, key, string(pattern))
+}
+
+func isOperatorObject(obj value.ObjValue) bool {
+	if len(obj) == 0 {
+		return false
+	}
+	for k := range obj {
+		if !strings.HasPrefix(k, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+func evalOperator(op string, actual, expected value.Value) bool {
+	switch op {
+	case "$eq":
+		return value.Equal(actual, expected)
+	case "$ne":
+		return !value.Equal(actual, expected)
+	case "$exists":
+		want := expected.Cast(value.BoolType) == value.True()
+		return value.IsNull(actual) != want
+	case "$in":
+		return elemIn(actual, expected)
+	case "$nin":
+		return !elemIn(actual, expected)
+	case "$gt", "$gte", "$lt", "$lte":
+		return compareOp(op, actual, expected)
+	case "$not":
+		return !evalConditionValue(actual, expected)
+	case "$size":
+		arr, ok := actual.(value.ArrValue)
+		if !ok {
+			return false
+		}
+		return value.Equal(value.Num(len(arr)), expected)
+	case "$elemMatch":
+		arr, ok := actual.(value.ArrValue)
+		if !ok {
+			return false
+		}
+		expObj, ok := expected.(value.ObjValue)
+		if !ok {
+			return false
+		}
+		for _, elem := range arr {
+			if condition(expObj).evalAgainst(elem) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// condition is a single object-shaped operator/sub-condition value, reused
+// by $elemMatch to test a scalar array element against either a nested
+// condition object or a direct operator map.
+type condition value.ObjValue
+
+func (c condition) evalAgainst(v value.Value) bool {
+	obj := value.ObjValue(c)
+	if isOperatorObject(obj) {
+		return evalConditionValue(v, obj)
+	}
+	asObj, ok := v.(value.ObjValue)
+	if !ok {
+		return false
+	}
+	for k, expected := range obj {
+		if !evalConditionValue(asObj.Path(splitPath(k)...), expected) {
+			return false
+		}
+	}
+	return true
+}
+
+func elemIn(actual, expected value.Value) bool {
+	arr, ok := expected.(value.ArrValue)
+	if !ok {
+		return false
+	}
+	for _, v := range arr {
+		if value.Equal(actual, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareOp(op string, actual, expected value.Value) bool {
+	an, aok := actual.Cast(value.NumType).(value.NumValue)
+	bn, bok := expected.Cast(value.NumType).(value.NumValue)
+	if !aok || !bok {
+		return false
+	}
+	af, bf := an.Float64(), bn.Float64()
+	switch op {
+	case "$gt":
+		return af > bf
+	case "$gte":
+		return af >= bf
+	case "$lt":
+		return af < bf
+	case "$lte":
+		return af <= bf
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package growthbook
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// decrypt reverses the AES-CBC encryption GrowthBook uses for encrypted
+// feature/experiment payloads: key is base64-encoded, and encrypted is
+// "<base64 iv>.<base64 ciphertext>".
+func decrypt(encrypted string, keyB64 string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(keyB64)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.SplitN(encrypted, ".", 2)
+	if len(parts) != 2 {
+		return "", errors.New("growthbook: invalid encrypted payload")
+	}
+	iv, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return "", errors.New("growthbook: invalid ciphertext length")
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	return unpadPKCS7(plaintext)
+}
+
+func unpadPKCS7(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", errors.New("growthbook: empty plaintext")
+	}
+	padLen := int(data[len(data)-1])
+	if padLen == 0 || padLen > len(data) {
+		return "", errors.New("growthbook: invalid PKCS7 padding")
+	}
+	if !bytes.Equal(data[len(data)-padLen:], bytes.Repeat([]byte{byte(padLen)}, padLen)) {
+		return "", errors.New("growthbook: invalid PKCS7 padding")
+	}
+	return string(data[:len(data)-padLen]), nil
+}
@@ -0,0 +1,64 @@
+package growthbook
+
+import "hash/fnv"
+
+// Experiment describes a running A/B test: a set of variations, the
+// attribute used to bucket users into them, and optional weighting/targeting.
+type Experiment struct {
+	Key           string    `json:"key"`
+	Variations    []any     `json:"variations,omitempty"`
+	Weights       []float64 `json:"weights,omitempty"`
+	Coverage      *float64  `json:"coverage,omitempty"`
+	HashAttribute string    `json:"hashAttribute,omitempty"`
+	Condition     Condition `json:"condition,omitempty"`
+}
+
+// ExperimentResult is the outcome of running a user through an Experiment.
+type ExperimentResult struct {
+	Value         any
+	VariationID   int
+	InExperiment  bool
+	HashUsed      bool
+	HashAttribute string
+	HashValue     string
+	Key           string
+	FeatureID     string
+}
+
+// hashFnv32a hashes s the same way the rest of the GrowthBook SDKs do, so
+// that a given (seed, attribute value) pair buckets a user into the same
+// variation across every language.
+func hashFnv32a(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
+
+// hashRatio returns a deterministic value in [0, 1) for (seed, value),
+// suitable for comparing against cumulative variation weights.
+func hashRatio(seed, val string) float64 {
+	n := hashFnv32a(val + seed)
+	return float64(n%10000) / 10000
+}
+
+func equalWeights(n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1 / float64(n)
+	}
+	return weights
+}
+
+// chooseVariation returns the index of the variation that ratio falls into,
+// given cumulative weights, or -1 if ratio falls outside all of them (e.g.
+// coverage below 1.0 excludes the user from the experiment).
+func chooseVariation(ratio float64, weights []float64) int {
+	cumulative := 0.0
+	for i, w := range weights {
+		cumulative += w
+		if ratio < cumulative {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,173 @@
+package growthbook
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// FeatureMap is a collection of features, keyed by feature id.
+type FeatureMap map[string]*Feature
+
+// Feature describes a single feature: a default value, plus an ordered list
+// of override rules evaluated in turn until one applies.
+type Feature struct {
+	DefaultValue any            `json:"defaultValue,omitempty"`
+	Rules        []*FeatureRule `json:"rules,omitempty"`
+}
+
+// UnmarshalJSON decodes Feature data through json.Decoder with UseNumber(),
+// so a DefaultValue, rule Force value, or rule Variations element containing
+// a large integer (e.g. a Snowflake/user id used as a force value) round-
+// trips exactly instead of being silently rounded to the nearest float64.
+// Numbers that fit a float64 exactly are still converted to float64,
+// matching encoding/json's normal behavior and keeping these fields' shape
+// stable for the common case of small numbers.
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var a struct {
+		DefaultValue any            `json:"defaultValue,omitempty"`
+		Rules        []*FeatureRule `json:"rules,omitempty"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&a); err != nil {
+		return err
+	}
+	f.DefaultValue = resolveJSONNumbers(a.DefaultValue)
+	for _, rule := range a.Rules {
+		rule.Force = resolveJSONNumbers(rule.Force)
+		for i, v := range rule.Variations {
+			rule.Variations[i] = resolveJSONNumbers(v)
+		}
+	}
+	f.Rules = a.Rules
+	return nil
+}
+
+// resolveJSONNumbers walks v (as decoded by a json.Decoder with UseNumber())
+// converting each json.Number leaf to a float64, unless the number is an
+// integer that fits an int64 but lies outside the range a float64 can
+// represent exactly, in which case it's kept as an int64. Integers beyond
+// the int64 range still become a (rounded) float64.
+func resolveJSONNumbers(v any) any {
+	switch vv := v.(type) {
+	case json.Number:
+		if i, err := vv.Int64(); err == nil && (i <= 1<<53 && i >= -(1<<53)) {
+			return float64(i)
+		} else if err == nil {
+			return i
+		}
+		f, _ := vv.Float64()
+		return f
+	case map[string]any:
+		out := make(map[string]any, len(vv))
+		for k, e := range vv {
+			out[k] = resolveJSONNumbers(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(vv))
+		for i, e := range vv {
+			out[i] = resolveJSONNumbers(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// FeatureRule is a single targeting/override rule within a Feature.
+type FeatureRule struct {
+	Condition     Condition `json:"condition,omitempty"`
+	Force         any       `json:"force,omitempty"`
+	Variations    []any     `json:"variations,omitempty"`
+	Weights       []float64 `json:"weights,omitempty"`
+	Coverage      *float64  `json:"coverage,omitempty"`
+	Key           string    `json:"key,omitempty"`
+	HashAttribute string    `json:"hashAttribute,omitempty"`
+}
+
+// FeatureResultSource records why EvalFeature returned the value it did.
+type FeatureResultSource string
+
+const (
+	UnknownFeatureResultSource     FeatureResultSource = "unknownFeature"
+	DefaultValueResultSource       FeatureResultSource = "defaultValue"
+	ForceResultSource              FeatureResultSource = "force"
+	ExperimentResultSource         FeatureResultSource = "experiment"
+	CyclicPrerequisiteResultSource FeatureResultSource = "cyclicPrerequisite"
+)
+
+// FeatureResult is the outcome of evaluating a single feature for the
+// current attributes.
+type FeatureResult struct {
+	Value            any
+	On               bool
+	Off              bool
+	Source           FeatureResultSource
+	RuleID           string
+	Experiment       *Experiment
+	ExperimentResult *ExperimentResult
+}
+
+func featureResultFromValue(v any, source FeatureResultSource) *FeatureResult {
+	on := truthyValue(v)
+	return &FeatureResult{
+		Value:  v,
+		On:     on,
+		Off:    !on,
+		Source: source,
+	}
+}
+
+func truthyValue(v any) bool {
+	return value.New(v).Cast(value.BoolType) == value.True()
+}
+
+// Condition is a (possibly nested) rule condition, decoded with json.Number
+// precision so large integer attribute ids embedded in conditions don't get
+// silently rounded to the nearest float64.
+type Condition map[string]value.Value
+
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	m := make(Condition, len(raw))
+	for k, v := range raw {
+		m[k] = value.New(v)
+	}
+	*c = m
+	return nil
+}
+
+// Eval reports whether attrs (and, for request-namespaced fields such as
+// "headers.User-Agent", reqCtx) satisfies every field of the condition.
+//
+// "url", "headers.<name>", "cookies.<name>", and "query.<name>" are reserved
+// namespaces routed to reqCtx instead of attrs -- but only when reqCtx is
+// set, so a client evaluated without a request context (e.g. a server-side
+// client that never called WithRequest) still lets a user attribute
+// literally named "url", "headers", "cookies", or "query" be matched as a
+// normal attribute.
+func (c Condition) Eval(attrs value.ObjValue, reqCtx *RequestContext) bool {
+	for path, expected := range c {
+		if reqCtx != nil {
+			if namespace, key, ok := parseRequestPath(path); ok {
+				if !evalRequestField(reqCtx, namespace, key, expected) {
+					return false
+				}
+				continue
+			}
+		}
+		actual := resolveConditionPath(attrs, path)
+		if !evalConditionValue(actual, expected) {
+			return false
+		}
+	}
+	return true
+}
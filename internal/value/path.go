@@ -0,0 +1,199 @@
+package value
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PathExpr resolves a gjson-inspired path expression against o, so rule
+// authors can reach into nested arrays (`orders.0.total`, `orders[0].total`)
+// without first flattening them into intermediate attributes. Supported
+// syntax:
+//
+//	a.b.c          dotted object keys
+//	a.0.b / a[0].b numeric array indices
+//	a.#            array length of a
+//	a.*            fan out: every element/value of a, as an ArrValue
+//	a.#(f==v)      first element of array a whose field f equals v
+//	a.#(f>v)       first element of array a whose field f is greater than v
+//
+// Any malformed or unresolvable expression yields Null() rather than a
+// panic or error, since conditions are untrusted, user-authored data.
+func (o ObjValue) PathExpr(expr string) (result Value) {
+	defer func() {
+		if recover() != nil {
+			result = Null()
+		}
+	}()
+
+	tokens := tokenizePathExpr(expr)
+	if len(tokens) == 0 {
+		return Null()
+	}
+	var cur Value = o
+	for _, tok := range tokens {
+		if tok == "" {
+			return Null()
+		}
+		cur = stepPathExpr(cur, tok)
+		if IsNull(cur) {
+			return Null()
+		}
+	}
+	return cur
+}
+
+// tokenizePathExpr splits a path expression on '.', treating "[...]" as
+// "." and "#(...)" as a single opaque token even though it may itself look
+// like it contains further structure.
+func tokenizePathExpr(expr string) []string {
+	var tokens []string
+	var buf strings.Builder
+	depth := 0
+	for _, r := range expr {
+		switch {
+		case r == '(':
+			depth++
+			buf.WriteRune(r)
+		case r == ')':
+			depth--
+			buf.WriteRune(r)
+		case r == '[' && depth == 0:
+			if buf.Len() > 0 {
+				tokens = append(tokens, buf.String())
+				buf.Reset()
+			}
+		case r == ']' && depth == 0:
+			if buf.Len() > 0 {
+				tokens = append(tokens, buf.String())
+				buf.Reset()
+			}
+		case r == '.' && depth == 0:
+			if buf.Len() > 0 {
+				tokens = append(tokens, buf.String())
+				buf.Reset()
+			}
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	if buf.Len() > 0 {
+		tokens = append(tokens, buf.String())
+	}
+	return tokens
+}
+
+func stepPathExpr(cur Value, tok string) Value {
+	switch {
+	case tok == "#":
+		arr, ok := cur.(ArrValue)
+		if !ok {
+			return Null()
+		}
+		return Num(len(arr))
+	case tok == "*":
+		switch v := cur.(type) {
+		case ArrValue:
+			return v
+		case ObjValue:
+			vals := make(ArrValue, 0, len(v))
+			for _, val := range v {
+				vals = append(vals, val)
+			}
+			return vals
+		default:
+			return Null()
+		}
+	case strings.HasPrefix(tok, "#(") && strings.HasSuffix(tok, ")"):
+		arr, ok := cur.(ArrValue)
+		if !ok {
+			return Null()
+		}
+		return evalArrFilter(arr, tok[2:len(tok)-1])
+	default:
+		if idx, err := strconv.Atoi(tok); err == nil {
+			arr, ok := cur.(ArrValue)
+			if !ok || idx < 0 || idx >= len(arr) {
+				return Null()
+			}
+			return arr[idx]
+		}
+		obj, ok := cur.(ObjValue)
+		if !ok {
+			return Null()
+		}
+		v, ok := obj[tok]
+		if !ok {
+			return Null()
+		}
+		return v
+	}
+}
+
+// evalArrFilter implements the "#(field==literal)" / "#(field>literal)"
+// predicate, returning the first element of arr whose field satisfies it,
+// or Null() if none do.
+func evalArrFilter(arr ArrValue, predicate string) Value {
+	for _, op := range []string{"==", "!=", ">=", "<=", ">", "<"} {
+		field, literal, found := strings.Cut(predicate, op)
+		if !found {
+			continue
+		}
+		field = strings.TrimSpace(field)
+		literal = strings.TrimSpace(literal)
+		expected := parsePredicateLiteral(literal)
+		for _, elem := range arr {
+			obj, ok := elem.(ObjValue)
+			if !ok {
+				continue
+			}
+			actual := obj.Path(field)
+			if matchPredicate(op, actual, expected) {
+				return elem
+			}
+		}
+		return Null()
+	}
+	return Null()
+}
+
+func parsePredicateLiteral(literal string) Value {
+	literal = strings.Trim(literal, `"'`)
+	if literal == "true" {
+		return True()
+	}
+	if literal == "false" {
+		return False()
+	}
+	if n, err := strconv.ParseFloat(literal, 64); err == nil {
+		return Num(n)
+	}
+	return Str(literal)
+}
+
+func matchPredicate(op string, actual, expected Value) bool {
+	switch op {
+	case "==":
+		return Equal(actual, expected)
+	case "!=":
+		return !Equal(actual, expected)
+	case ">", ">=", "<", "<=":
+		an, aok := actual.Cast(NumType).(NumValue)
+		bn, bok := expected.Cast(NumType).(NumValue)
+		if !aok || !bok {
+			return false
+		}
+		af, bf := an.Float64(), bn.Float64()
+		switch op {
+		case ">":
+			return af > bf
+		case ">=":
+			return af >= bf
+		case "<":
+			return af < bf
+		case "<=":
+			return af <= bf
+		}
+	}
+	return false
+}
@@ -0,0 +1,367 @@
+// Package value implements a small dynamically-typed value model used to
+// represent feature and experiment attributes, JSON feature definitions, and
+// rule conditions. It mirrors the loose typing and coercion rules of the
+// GrowthBook condition language (itself modeled after JavaScript's `==`-style
+// coercions) so that conditions authored against the JS SDK behave the same
+// way here.
+package value
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ValueType identifies the dynamic type of a Value.
+type ValueType int
+
+const (
+	NullType ValueType = iota
+	BoolType
+	NumType
+	StrType
+	ArrType
+	ObjType
+)
+
+// Value is the common interface implemented by all value types.
+type Value interface {
+	Type() ValueType
+	// Cast converts the value to vtype, following the same coercion rules as
+	// JavaScript's `!!`, `*1` and `+""` operators. Conversions that have no
+	// reasonable JS analog (e.g. object/array targets) return Null().
+	Cast(vtype ValueType) Value
+	// String renders the value the way JS string concatenation would.
+	String() string
+}
+
+// NullValue represents the absence of a value.
+type NullValue struct{}
+
+// BoolValue is a boolean value.
+type BoolValue bool
+
+// NumValue is a numeric value. It keeps the original JSON representation (via
+// Number) when the value was decoded from JSON with json.Number, so integers
+// that don't fit exactly in a float64 (e.g. large Snowflake/user IDs) aren't
+// silently rounded.
+type NumValue struct {
+	Number json.Number
+}
+
+// StrValue is a string value.
+type StrValue string
+
+// ArrValue is an ordered list of values.
+type ArrValue []Value
+
+// ObjValue is a string-keyed map of values.
+type ObjValue map[string]Value
+
+func Null() Value { return NullValue{} }
+
+func Bool(v bool) Value { return BoolValue(v) }
+func True() Value       { return BoolValue(true) }
+func False() Value      { return BoolValue(false) }
+
+// Num builds a numeric value from any Go numeric type.
+func Num[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | float32 | float64](v T) Value {
+	return NumValue{Number: numberOf(v)}
+}
+
+// NumFromJSON builds a numeric value directly from a json.Number, preserving
+// its original textual representation exactly (used by the JSON decoder path
+// so large integers round-trip without going through float64).
+func NumFromJSON(n json.Number) Value {
+	return NumValue{Number: n}
+}
+
+func Str(v string) Value { return StrValue(v) }
+
+// Arr builds an array value, converting each element with New.
+func Arr(vs ...any) Value {
+	arr := make(ArrValue, len(vs))
+	for i, v := range vs {
+		arr[i] = New(v)
+	}
+	return arr
+}
+
+// Obj builds an object value, converting each field with New.
+func Obj(m map[string]any) Value {
+	obj := make(ObjValue, len(m))
+	for k, v := range m {
+		obj[k] = New(v)
+	}
+	return obj
+}
+
+func numberOf(v any) json.Number {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(rv.Uint(), 10))
+	default:
+		return json.Number(strconv.FormatFloat(rv.Float(), 'g', -1, 64))
+	}
+}
+
+// New converts an arbitrary Go value (typically the result of decoding JSON,
+// or a user-supplied attribute) into a Value. json.Number inputs are kept
+// exactly; other integer/float kinds are converted via numberOf so that
+// equivalent numeric inputs (int64(10), myint(10), 10.0) all compare equal.
+func New(v any) Value {
+	switch vv := v.(type) {
+	case nil:
+		return Null()
+	case Value:
+		return vv
+	case json.Number:
+		return NumValue{Number: vv}
+	case bool:
+		return BoolValue(vv)
+	case string:
+		return StrValue(vv)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return BoolValue(rv.Bool())
+	case reflect.String:
+		return StrValue(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return NumValue{Number: numberOf(v)}
+	case reflect.Slice, reflect.Array:
+		arr := make(ArrValue, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			arr[i] = New(rv.Index(i).Interface())
+		}
+		return arr
+	case reflect.Map:
+		obj := make(ObjValue, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			obj[fmt.Sprint(iter.Key().Interface())] = New(iter.Value().Interface())
+		}
+		return obj
+	}
+	return Null()
+}
+
+func IsNull(v Value) bool { return v.Type() == NullType }
+func IsBool(v Value) bool { return v.Type() == BoolType }
+func IsNum(v Value) bool  { return v.Type() == NumType }
+func IsStr(v Value) bool  { return v.Type() == StrType }
+func IsArr(v Value) bool  { return v.Type() == ArrType }
+func IsObj(v Value) bool  { return v.Type() == ObjType }
+
+func (NullValue) Type() ValueType { return NullType }
+func (BoolValue) Type() ValueType { return BoolType }
+func (NumValue) Type() ValueType  { return NumType }
+func (StrValue) Type() ValueType  { return StrType }
+func (ArrValue) Type() ValueType  { return ArrType }
+func (ObjValue) Type() ValueType  { return ObjType }
+
+// Float64 returns the value as a float64, losing precision for integers
+// outside the float64 exact-integer range. Prefer comparing Number directly
+// (via Equal) when exactness matters.
+func (n NumValue) Float64() float64 {
+	f, err := n.Number.Float64()
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// Int64 returns the value as an int64 when it represents one exactly.
+func (n NumValue) Int64() (int64, bool) {
+	i, err := n.Number.Int64()
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+func (NullValue) String() string { return "null" }
+func (b BoolValue) String() string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+func (n NumValue) String() string { return string(n.Number) }
+func (s StrValue) String() string { return string(s) }
+func (a ArrValue) String() string {
+	parts := make([]string, len(a))
+	for i, v := range a {
+		parts[i] = v.String()
+	}
+	return strings.Join(parts, ",")
+}
+func (ObjValue) String() string { return "Object" }
+
+// Path walks a sequence of object keys, returning Null() as soon as a key is
+// missing or an intermediate value isn't an object.
+func (o ObjValue) Path(keys ...string) Value {
+	var cur Value = o
+	for _, k := range keys {
+		obj, ok := cur.(ObjValue)
+		if !ok {
+			return Null()
+		}
+		v, ok := obj[k]
+		if !ok {
+			return Null()
+		}
+		cur = v
+	}
+	return cur
+}
+
+func (NullValue) Cast(vtype ValueType) Value { return castFrom(NullValue{}, vtype) }
+func (b BoolValue) Cast(vtype ValueType) Value { return castFrom(b, vtype) }
+func (n NumValue) Cast(vtype ValueType) Value  { return castFrom(n, vtype) }
+func (s StrValue) Cast(vtype ValueType) Value  { return castFrom(s, vtype) }
+func (a ArrValue) Cast(vtype ValueType) Value  { return castFrom(a, vtype) }
+func (o ObjValue) Cast(vtype ValueType) Value  { return castFrom(o, vtype) }
+
+func castFrom(v Value, vtype ValueType) Value {
+	if v.Type() == vtype {
+		return v
+	}
+	switch vtype {
+	case BoolType:
+		return Bool(toBool(v))
+	case NumType:
+		if n, ok := toNum(v); ok {
+			return n
+		}
+		return Null()
+	case StrType:
+		if s, ok := toStr(v); ok {
+			return Str(s)
+		}
+		return Null()
+	default:
+		// No JS-analogous coercion exists into arrays/objects.
+		return Null()
+	}
+}
+
+func toBool(v Value) bool {
+	switch vv := v.(type) {
+	case NullValue:
+		return false
+	case BoolValue:
+		return bool(vv)
+	case NumValue:
+		return vv.Float64() != 0
+	case StrValue:
+		return string(vv) != ""
+	case ArrValue, ObjValue:
+		return true
+	}
+	return false
+}
+
+func toNum(v Value) (Value, bool) {
+	switch vv := v.(type) {
+	case NullValue:
+		return Num(0), true
+	case BoolValue:
+		if vv {
+			return Num(1), true
+		}
+		return Num(0), true
+	case NumValue:
+		return vv, true
+	case StrValue:
+		s := strings.TrimSpace(string(vv))
+		if s == "" {
+			return Num(0), true
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, false
+		}
+		return Num(f), true
+	case ArrValue:
+		switch len(vv) {
+		case 0:
+			return Num(0), true
+		case 1:
+			return toNum(vv[0])
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func toStr(v Value) (string, bool) {
+	switch vv := v.(type) {
+	case NullValue:
+		return "null", true
+	case BoolValue:
+		return vv.String(), true
+	case NumValue:
+		return vv.String(), true
+	case StrValue:
+		return string(vv), true
+	case ArrValue:
+		return vv.String(), true
+	}
+	return "", false
+}
+
+// Equal reports whether a and b have the same type and value. Numbers are
+// compared via their decoded float64 value, except when both sides carry an
+// exact int64 representation, in which case those are compared directly so
+// large integers aren't subject to float64 rounding.
+func Equal(a, b Value) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch av := a.(type) {
+	case NumValue:
+		bv := b.(NumValue)
+		ai, aok := av.Int64()
+		bi, bok := bv.Int64()
+		if aok && bok {
+			return ai == bi
+		}
+		return av.Float64() == bv.Float64()
+	case ArrValue:
+		bv := b.(ArrValue)
+		if len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !Equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case ObjValue:
+		bv := b.(ObjValue)
+		if len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			bvv, ok := bv[k]
+			if !ok || !Equal(v, bvv) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
@@ -1,8 +1,12 @@
 package value
 
 import (
-	"github.com/stretchr/testify/require"
+	"encoding/json"
+	"strings"
 	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestValueConstructor(t *testing.T) {
@@ -183,6 +187,26 @@ func TestValueEqual(t *testing.T) {
 	require.False(t, Equal(ObjValue{"f1": Num(1)}, ObjValue{"f1": Num(1), "f2": Num(2)}))
 }
 
+func TestValueLargeIntegerPrecision(t *testing.T) {
+	// 9007199254740993 is 2^53+1, the smallest positive integer that can't
+	// be represented exactly as a float64.
+	const large = "9007199254740993"
+
+	fromJSON := NumFromJSON(json.Number(large))
+	fromAttr := Num(int64(9007199254740993))
+
+	require.True(t, Equal(fromJSON, fromAttr), "json.Number and int64 representations of the same large integer must compare equal")
+
+	i, ok := fromJSON.(NumValue).Int64()
+	require.True(t, ok)
+	require.Equal(t, int64(9007199254740993), i)
+
+	// float64(9007199254740993) rounds down to 9007199254740992, so the two
+	// neighboring integers would incorrectly compare equal if Equal ever
+	// fell back to float64 comparison for exact int64 values.
+	require.False(t, Equal(fromJSON, Num(int64(9007199254740992))))
+}
+
 func TestObjValuePath(t *testing.T) {
 	obj := ObjValue{
 		"user_id": Num(1),
@@ -204,6 +228,90 @@ func TestObjValuePath(t *testing.T) {
 	require.Equal(t, Str("us"), obj.Path(path...))
 }
 
+func TestObjValuePathExpr(t *testing.T) {
+	obj := ObjValue{
+		"user": ObjValue{
+			"name": Str("Bob"),
+		},
+		"orders": Arr(
+			ObjValue{"sku": Str("a"), "total": Num(10)},
+			ObjValue{"sku": Str("b"), "total": Num(20)},
+		),
+		"tags": Arr("user", "new"),
+	}
+
+	require.Equal(t, Str("Bob"), obj.PathExpr("user.name"))
+	require.Equal(t, Num(10), obj.PathExpr("orders.0.total"))
+	require.Equal(t, Num(10), obj.PathExpr("orders[0].total"))
+	require.Equal(t, Num(2), obj.PathExpr("orders.#"))
+	require.Equal(t, Num(20), obj.PathExpr(`orders.#(sku=="b").total`))
+	require.Equal(t, Null(), obj.PathExpr(`orders.#(sku=="z").total`))
+	require.Equal(t, Null(), obj.PathExpr("orders.10.total"))
+	require.Equal(t, Null(), obj.PathExpr("user.missing.field"))
+}
+
+func pathExprFuzzObj() ObjValue {
+	return ObjValue{
+		"user": ObjValue{"name": Str("Bob")},
+		"orders": Arr(
+			ObjValue{"sku": Str("a"), "total": Num(10)},
+		),
+	}
+}
+
+// FuzzPathExpr feeds arbitrary byte sequences (seeded with a handful of
+// valid and malformed expressions covering keys, indices, "#" and "=="
+// filters, plus whatever
+// the fuzzer discovers on its own via `go test -fuzz=FuzzPathExpr`) as path
+// expressions, and requires that PathExpr only ever return a typed Value
+// (Null() on failure), never panic.
+func FuzzPathExpr(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		".",
+		"..",
+		"#",
+		"#(",
+		"#()",
+		"#(==)",
+		"[",
+		"]",
+		"[[[[",
+		"user.name",
+		"orders.0.total",
+		"orders[0].total",
+		"a.b.c.d.e.f.g",
+		"orders.#(sku==)",
+		"orders.#(==\"a\")",
+		string([]byte{0xff, 0xfe, 0x00, '.', '#', '('}),
+		strings.Repeat("a.", 1000) + "#",
+	} {
+		f.Add(seed)
+	}
+
+	obj := pathExprFuzzObj()
+	f.Fuzz(func(t *testing.T, expr string) {
+		require.NotPanics(t, func() {
+			_ = obj.PathExpr(expr)
+		})
+	})
+}
+
+// TestObjValuePathExprRandom runs the same property under `go test` without
+// requiring -fuzz: testing/quick generates arbitrary strings (unlike a
+// fuzz seed corpus, these actually vary on every run) and PathExpr must
+// survive every one of them without panicking.
+func TestObjValuePathExprRandom(t *testing.T) {
+	obj := pathExprFuzzObj()
+	property := func(expr string) bool {
+		require.NotPanics(t, func() {
+			_ = obj.PathExpr(expr)
+		})
+		return true
+	}
+	require.NoError(t, quick.Check(property, &quick.Config{MaxCount: 2000}))
+}
+
 func TestValueString(t *testing.T) {
 	tests := []struct {
 		v any
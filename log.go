@@ -0,0 +1,11 @@
+package growthbook
+
+import "log"
+
+// logError reports a recoverable internal error (e.g. malformed feature
+// JSON) without aborting the calling evaluation. GrowthBook favors
+// degrading a single feature/condition over failing an entire request, so
+// callers log and fall back rather than returning an error.
+func logError(args ...interface{}) {
+	log.Println(append([]interface{}{"[growthbook]"}, args...)...)
+}
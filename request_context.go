@@ -0,0 +1,150 @@
+package growthbook
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestContext carries the per-request data that URL-targeted experiments
+// and feature rules can match against: the request URL, plus headers and
+// cookies. It generalizes the URL-only targeting evalSimpleURLTarget has
+// always done to the rest of an incoming HTTP request, so a rule condition
+// can target a User-Agent header, a feature-flag cookie, or an X-Country
+// header the same way it already targets the URL path or query string.
+type RequestContext struct {
+	URL     *url.URL
+	Headers map[string]string
+	Cookies map[string]string
+}
+
+// requestContextFromRequest builds a RequestContext from an *http.Request,
+// flattening its headers and cookies into the plain string maps
+// RequestContext uses for matching.
+func requestContextFromRequest(req *http.Request) *RequestContext {
+	headers := make(map[string]string, len(req.Header))
+	for name := range req.Header {
+		headers[name] = req.Header.Get(name)
+	}
+	cookies := make(map[string]string, len(req.Cookies()))
+	for _, c := range req.Cookies() {
+		cookies[c.Name] = c.Value
+	}
+	return &RequestContext{
+		URL:     req.URL,
+		Headers: headers,
+		Cookies: cookies,
+	}
+}
+
+// WithRequestContext sets the initial request context on a client under
+// construction; see Client.WithRequestContext.
+func WithRequestContext(reqCtx *RequestContext) ClientOption {
+	return func(c *Client) error {
+		c.requestContext = reqCtx
+		return nil
+	}
+}
+
+// WithRequestContext returns a child client whose URL/header/cookie
+// targeting rules are evaluated against reqCtx instead of the receiver's
+// request context.
+func (c *Client) WithRequestContext(reqCtx *RequestContext) (*Client, error) {
+	nc := c.clone()
+	nc.requestContext = reqCtx
+	return nc, nil
+}
+
+// WithRequest returns a child client whose request context is populated
+// from req in one shot (URL, headers and cookies), a convenience over
+// building a RequestContext by hand for every incoming *http.Request.
+func (c *Client) WithRequest(req *http.Request) (*Client, error) {
+	return c.WithRequestContext(requestContextFromRequest(req))
+}
+
+// evalRequestTarget reports whether reqCtx satisfies a single targeting
+// condition field of the form "url", "headers.<name>", "cookies.<name>", or
+// "query.<name>", using the same glob/wildcard pattern language
+// evalSimpleURLPart already applies to URL paths and query parameters.
+func evalRequestTarget(reqCtx *RequestContext, namespace, key, pattern string) bool {
+	actual, ok := requestFieldValue(reqCtx, namespace, key)
+	if !ok {
+		return false
+	}
+	if namespace == "url" {
+		return evalSimpleURLTarget(reqCtx.URL, pattern)
+	}
+	return evalSimpleURLPart(actual, pattern, false)
+}
+
+// requestFieldValue looks up a single request-namespaced field ("headers",
+// "cookies", "query", or "url") on reqCtx, reporting ok=false if reqCtx (or
+// the relevant part of it, such as reqCtx.URL) is nil, or if the named
+// header/cookie/query key isn't present.
+func requestFieldValue(reqCtx *RequestContext, namespace, key string) (actual string, ok bool) {
+	if reqCtx == nil {
+		return "", false
+	}
+	switch namespace {
+	case "url":
+		if reqCtx.URL == nil {
+			return "", false
+		}
+		return reqCtx.URL.String(), true
+	case "headers":
+		return lookupCaseInsensitive(reqCtx.Headers, key)
+	case "cookies":
+		actual, ok := reqCtx.Cookies[key]
+		return actual, ok
+	case "query":
+		if reqCtx.URL == nil {
+			return "", false
+		}
+		values := reqCtx.URL.Query()
+		if _, ok := values[key]; !ok {
+			return "", false
+		}
+		return values.Get(key), true
+	default:
+		return "", false
+	}
+}
+
+// requestNamespaces are the condition-path prefixes Condition.Eval routes to
+// evalRequestField instead of plain attribute lookup.
+var requestNamespaces = map[string]bool{
+	"url":     true,
+	"headers": true,
+	"cookies": true,
+	"query":   true,
+}
+
+// parseRequestPath splits a condition field path ("headers.User-Agent") into
+// its namespace ("headers") and key ("User-Agent"), reporting ok=false if
+// the path isn't one of the recognized request namespaces. Except for "url"
+// (which targets the whole request URL and so takes no key), a namespace
+// word with no "." separator is treated as a plain attribute name rather
+// than a request-namespaced path, so a user attribute literally named
+// "headers", "cookies", or "query" isn't shadowed.
+func parseRequestPath(path string) (namespace, key string, ok bool) {
+	namespace, key, found := strings.Cut(path, ".")
+	if !requestNamespaces[namespace] {
+		return namespace, key, false
+	}
+	if namespace == "url" {
+		return namespace, key, true
+	}
+	return namespace, key, found
+}
+
+func lookupCaseInsensitive(m map[string]string, key string) (string, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if len(k) == len(key) && http.CanonicalHeaderKey(k) == http.CanonicalHeaderKey(key) {
+			return v, true
+		}
+	}
+	return "", false
+}
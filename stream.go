@@ -0,0 +1,230 @@
+package growthbook
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StreamOptions configures StartStream.
+type StreamOptions struct {
+	// URL is the GrowthBook-compatible SSE endpoint to connect to (typically
+	// an API host's "/sub/<client-key>" route).
+	URL string
+	// DecryptionKey, if set, decrypts "features"/"experiments" events the
+	// same way SetEncryptedJSONFeatures does.
+	DecryptionKey string
+	// ReconnectBackoff is the delay before retrying after a dropped
+	// connection or a transient HTTP error. Defaults to 5 seconds.
+	ReconnectBackoff time.Duration
+}
+
+// FeatureUpdateHook is called every time StartStream processes a payload,
+// with the feature maps from before and after it, so callers can observe
+// feature changes (e.g. for logging or cache invalidation) without polling
+// EvalFeature themselves. A payload the dateUpdated stale-guard rejects
+// still calls it, with old and new being the same map.
+type FeatureUpdateHook func(old, new FeatureMap)
+
+// StartStream opens an SSE connection to a GrowthBook-compatible streaming
+// endpoint and applies "features"/"experiments" events to the client as
+// they arrive, through the same dateUpdated stale-guard
+// UpdateFromApiResponseJSON already uses -- so an out-of-order event from a
+// reconnect can't regress the client back to older data. If the server
+// responds with a non-event-stream content type, it degrades to polling
+// opts.URL every opts.ReconnectBackoff instead, on the assumption the
+// endpoint doesn't support streaming.
+//
+// StartStream runs until ctx is canceled or it hits a permanent error (e.g.
+// a malformed URL); transient errors (a dropped connection, a failed poll
+// request) are retried after opts.ReconnectBackoff.
+func (c *Client) StartStream(ctx context.Context, opts StreamOptions) error {
+	if opts.ReconnectBackoff <= 0 {
+		opts.ReconnectBackoff = 5 * time.Second
+	}
+	if _, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.URL, nil); err != nil {
+		return err
+	}
+
+	for {
+		err := c.runStream(ctx, opts)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logError("growthbook stream error, reconnecting", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.ReconnectBackoff):
+		}
+	}
+}
+
+// Subscribe registers hook to be called whenever StartStream processes a
+// payload, whether received over the SSE stream or via the polling
+// fallback, including one the stale-guard rejects. It does not fire for a direct UpdateFromApiResponseJSON call
+// made outside of StartStream.
+func (c *Client) Subscribe(hook FeatureUpdateHook) {
+	c.data.mu.Lock()
+	defer c.data.mu.Unlock()
+	c.data.updateHooks = append(c.data.updateHooks, hook)
+}
+
+// runStream performs a single connection attempt. It returns when ctx is
+// canceled, the server closes the stream, or a request fails; StartStream
+// reconnects in every case except a canceled ctx.
+func (c *Client) runStream(ctx context.Context, opts StreamOptions) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.URL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("growthbook: stream request failed: %s", resp.Status)
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return c.consumeEventStream(ctx, resp.Body, opts)
+	}
+
+	// Server doesn't support streaming; apply this response, then keep
+	// polling the same URL on opts.ReconnectBackoff until ctx is canceled.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := c.applyStreamPayload(opts, string(body)); err != nil {
+		logError("growthbook poll: failed to apply response", err)
+	}
+	return c.pollLoop(ctx, opts)
+}
+
+// pollLoop repeatedly fetches opts.URL every opts.ReconnectBackoff and
+// applies the response, until ctx is canceled. A failed fetch or apply is
+// logged and retried on the next tick rather than aborting the loop.
+func (c *Client) pollLoop(ctx context.Context, opts StreamOptions) error {
+	ticker := time.NewTicker(opts.ReconnectBackoff)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			body, err := c.fetchOnce(ctx, opts)
+			if err != nil {
+				logError("growthbook poll: request failed", err)
+				continue
+			}
+			if err := c.applyStreamPayload(opts, string(body)); err != nil {
+				logError("growthbook poll: failed to apply response", err)
+			}
+		}
+	}
+}
+
+// fetchOnce performs a single GET of opts.URL, returning its body.
+func (c *Client) fetchOnce(ctx context.Context, opts StreamOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("growthbook: poll request failed: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// consumeEventStream reads SSE "event: <name>\ndata: <payload>\n\n" frames
+// from r, applying "features"/"experiments" events as they're parsed.
+func (c *Client) consumeEventStream(ctx context.Context, r io.Reader, opts StreamOptions) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var event strings.Builder
+	var data strings.Builder
+
+	flush := func() error {
+		defer func() {
+			event.Reset()
+			data.Reset()
+		}()
+		if data.Len() == 0 {
+			return nil
+		}
+		switch event.String() {
+		case "features", "experiments", "":
+			return c.applyStreamPayload(opts, data.String())
+		default:
+			return nil
+		}
+	}
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if err := flush(); err != nil {
+				logError("growthbook stream: failed to apply event", err)
+			}
+		case strings.HasPrefix(line, "event:"):
+			event.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "event:")))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// applyStreamPayload decrypts payload if needed and feeds it through
+// UpdateFromApiResponseJSON, so stream events are subject to the exact same
+// dateUpdated stale-response guard as a manual UpdateFromApiResponseJSON
+// call.
+func (c *Client) applyStreamPayload(opts StreamOptions, payload string) error {
+	if opts.DecryptionKey != "" {
+		decrypted, err := decrypt(payload, opts.DecryptionKey)
+		if err != nil {
+			return err
+		}
+		payload = decrypted
+	}
+
+	old := c.data.getFeatures()
+	if err := c.UpdateFromApiResponseJSON(payload); err != nil {
+		return err
+	}
+
+	c.data.mu.Lock()
+	hooks := append([]FeatureUpdateHook(nil), c.data.updateHooks...)
+	c.data.mu.Unlock()
+	for _, hook := range hooks {
+		hook(old, c.data.getFeatures())
+	}
+	return nil
+}
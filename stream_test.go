@@ -0,0 +1,115 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartStreamAppliesOnlyNewestOutOfOrderEvent(t *testing.T) {
+	newerEvent := `{"features":{"foo":{"defaultValue":"newer"}},"experiments":[],"dateUpdated":"2000-05-02T00:00:00Z"}`
+	olderEvent := `{"features":{"foo":{"defaultValue":"older"}},"experiments":[],"dateUpdated":"2000-05-01T00:00:00Z"}`
+
+	sent := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+
+		// Write the newer event first, then a stale one -- out of order
+		// relative to the dateUpdated values they carry -- and confirm the
+		// client never regresses back to the stale value.
+		fmt.Fprintf(w, "event: features\ndata: %s\n\n", newerEvent)
+		flusher.Flush()
+		fmt.Fprintf(w, "event: features\ndata: %s\n\n", olderEvent)
+		flusher.Flush()
+
+		close(sent)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := NewClient(ctx)
+	require.Nil(t, err)
+
+	updates := make(chan FeatureMap, 2)
+	client.Subscribe(func(old, new FeatureMap) {
+		updates <- new
+	})
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- client.StartStream(ctx, StreamOptions{URL: server.URL})
+	}()
+
+	<-sent
+	for i := 0; i < 2; i++ {
+		select {
+		case <-updates:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for stream update")
+		}
+	}
+
+	require.Equal(t, &Feature{DefaultValue: "newer"}, client.data.features["foo"])
+
+	cancel()
+	select {
+	case <-streamErr:
+	case <-time.After(2 * time.Second):
+		t.Fatal("StartStream did not return after context cancellation")
+	}
+}
+
+func TestStartStreamDegradesToPollOnNonEventStreamResponse(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"features":{"foo":{"defaultValue":"polled-%d"}},"experiments":[],"dateUpdated":"2000-05-%02dT00:00:00Z"}`, n, n)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := NewClient(ctx)
+	require.Nil(t, err)
+
+	updates := make(chan FeatureMap, 10)
+	client.Subscribe(func(old, new FeatureMap) {
+		updates <- new
+	})
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- client.StartStream(ctx, StreamOptions{URL: server.URL, ReconnectBackoff: 10 * time.Millisecond})
+	}()
+
+	// The fallback must keep polling (not fetch once and stop): wait for a
+	// second update, which only a real poll loop produces.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-updates:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for a poll update")
+		}
+	}
+	require.GreaterOrEqual(t, atomic.LoadInt32(&requests), int32(2))
+
+	cancel()
+	select {
+	case <-streamErr:
+	case <-time.After(2 * time.Second):
+		t.Fatal("StartStream did not return after context cancellation")
+	}
+}
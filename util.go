@@ -1,6 +1,7 @@
 package growthbook
 
 import (
+	"encoding/json"
 	"net/url"
 	"reflect"
 	"regexp"
@@ -164,8 +165,22 @@ func jsonBool(v interface{}, typeName string, fieldName string) (bool, bool) {
 	return false, false
 }
 
+// jsonAsFloat accepts either of the two shapes a decoded JSON number can
+// take depending on whether the decoder was configured with UseNumber():
+// float64 (the default), or json.Number (precision-preserving).
+func jsonAsFloat(v interface{}) (float64, bool) {
+	switch tmp := v.(type) {
+	case float64:
+		return tmp, true
+	case json.Number:
+		f, err := tmp.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
 func jsonInt(v interface{}, typeName string, fieldName string) (int, bool) {
-	tmp, ok := v.(float64)
+	tmp, ok := jsonAsFloat(v)
 	if ok {
 		return int(tmp), true
 	}
@@ -174,7 +189,7 @@ func jsonInt(v interface{}, typeName string, fieldName string) (int, bool) {
 }
 
 func jsonFloat(v interface{}, typeName string, fieldName string) (float64, bool) {
-	tmp, ok := v.(float64)
+	tmp, ok := jsonAsFloat(v)
 	if ok {
 		return tmp, true
 	}
@@ -183,7 +198,7 @@ func jsonFloat(v interface{}, typeName string, fieldName string) (float64, bool)
 }
 
 func jsonMaybeFloat(v interface{}, typeName string, fieldName string) (*float64, bool) {
-	tmp, ok := v.(float64)
+	tmp, ok := jsonAsFloat(v)
 	if ok {
 		return &tmp, true
 	}
@@ -199,7 +214,7 @@ func jsonFloatArray(v interface{}, typeName string, fieldName string) ([]float64
 	}
 	fvals := make([]float64, len(vals))
 	for i := range vals {
-		tmp, ok := vals[i].(float64)
+		tmp, ok := jsonAsFloat(vals[i])
 		if !ok {
 			logError("Invalid JSON data type", typeName, fieldName)
 			return nil, false